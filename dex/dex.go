@@ -0,0 +1,99 @@
+// Package dex wraps decred.org/dcrdex/client/core so the rest of godcr can
+// treat the DEX client the same way it already treats wallet.Wallet: a long
+// lived object that reports state changes on a channel rather than through
+// callbacks, so ui.Window.Loop can select on it alongside wallet.Send and
+// wallet.Sync.
+package dex
+
+import (
+	"decred.org/dcrdex/client/core"
+
+	"github.com/raedahgroup/godcr/wallet"
+)
+
+// UpdateStage identifies the kind of DEX update sent on Notifications.
+type UpdateStage int
+
+const (
+	// NotificationReceived is sent for every core.Notification Core emits
+	// (order status changes, matches, bond confirmations, connectivity).
+	NotificationReceived UpdateStage = iota
+)
+
+// Update is delivered on DEX.Notifications, mirroring the wallet.Update
+// shape consumed in ui.Window.Loop.
+type Update struct {
+	Stage        UpdateStage
+	Notification core.Notification
+}
+
+// DEX wraps a dcrdex client Core configured from a core.Config. Core is not
+// connected to the multiwallet backing wallet.Wallet; it uses whatever
+// wallets the Config gives it.
+type DEX struct {
+	core          *core.Core
+	Notifications chan Update
+}
+
+// Start initializes Core from cfg and begins forwarding its notification
+// feed on the returned DEX's Notifications channel. wal is not used: Core is
+// not set up against wal's multiwallet. Callers must call Shutdown on
+// system.DestroyEvent.
+func Start(cfg *core.Config, wal *wallet.Wallet) (*DEX, error) {
+	c, err := core.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DEX{
+		core:          c,
+		Notifications: make(chan Update, 32),
+	}
+
+	go c.Run(cfg.Context)
+	go d.relayNotifications()
+
+	return d, nil
+}
+
+// relayNotifications owns Notifications for its entire lifetime: it is the
+// only goroutine that sends on it, and the only one that closes it, and it
+// only does so once feed.C itself has been drained and closed. This keeps
+// Shutdown from ever racing a send here with a close there.
+func (d *DEX) relayNotifications() {
+	feed := d.core.NotificationFeed()
+	defer feed.Close()
+	defer close(d.Notifications)
+
+	for n := range feed.C {
+		d.Notifications <- Update{Stage: NotificationReceived, Notification: n}
+	}
+}
+
+// Shutdown stops Core and releases its resources. It should be called once,
+// on system.DestroyEvent. Core.Shutdown closes the notification feed, which
+// ends relayNotifications' loop and, only then, closes Notifications.
+func (d *DEX) Shutdown() {
+	d.core.Shutdown()
+}
+
+// Markets returns the exchanges and markets currently known to Core, for
+// populating the market-selection widget on the DEX page.
+func (d *DEX) Markets() map[string]*core.Exchange {
+	return d.core.Exchanges()
+}
+
+// Register registers godcr with a DEX server and posts the bond form
+// requires, per the server's registration fee/bond asset. It blocks until
+// the bond transaction has been broadcast; confirmation is reported
+// asynchronously via Notifications like any other core.Notification.
+func (d *DEX) Register(form *core.PostBondForm) (*core.PostBondResult, error) {
+	return d.core.PostBond(form)
+}
+
+// PlaceOrder submits a limit or market order to the given market, returning
+// the resulting order. Fills and status changes arrive later on
+// Notifications.
+func (d *DEX) PlaceOrder(form *core.TradeForm) (*core.Order, error) {
+	return d.core.Trade(form.Pass, form)
+}
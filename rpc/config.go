@@ -0,0 +1,43 @@
+package rpc
+
+import "github.com/raedahgroup/godcr/wallet"
+
+// Config controls whether the RPC server runs and where it listens. It is
+// read from the same config file/flags as the rest of godcr; a zero Config
+// leaves the server disabled so default runs behave exactly as before.
+type Config struct {
+	Enabled       bool
+	ListenAddress string // defaults to loopback-only (see defaultListenAddress) when empty
+
+	// AuthToken gates every connection behind a shared secret: a client must
+	// send it as the first line of the connection before any RPC method is
+	// served. This is deliberately the same "first cut" tradeoff as the rest
+	// of this package (see server.go's doc comment) -- btcwallet's rpcserver
+	// gets this via TLS + client certs, which is the real follow-up, but a
+	// shared secret is enough to stop an arbitrary local process from
+	// driving Send/SignMessage over a bare, unauthenticated TCP port.
+	AuthToken string
+}
+
+// StartIfEnabled starts the RPC server in the background when cfg.Enabled
+// is set, returning the running Server so the caller (either the Gio UI
+// process or the --headless entry point) can Close it on shutdown. It is a
+// no-op returning a nil Server when the feature is disabled.
+func StartIfEnabled(cfg Config, wal *wallet.Wallet) (*Server, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	server := NewServer(wal, cfg.AuthToken)
+	if err := server.Listen(cfg.ListenAddress); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := server.Serve(); err != nil {
+			log.Error("rpc: " + err.Error())
+		}
+	}()
+
+	return server, nil
+}
@@ -0,0 +1,5 @@
+package rpc
+
+import "github.com/decred/slog"
+
+var log = slog.Disabled
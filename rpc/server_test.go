@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"testing"
+)
+
+// echoService is a minimal stdlib net/rpc service used in place of
+// walletService so these tests can exercise serveConn's auth handshake
+// without needing a real wallet.Wallet.
+type echoService struct{}
+
+func (echoService) Echo(arg string, reply *string) error {
+	*reply = arg
+	return nil
+}
+
+func newTestRPCServer(t *testing.T) *rpc.Server {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("Echo", echoService{}); err != nil {
+		t.Fatalf("RegisterName: %v", err)
+	}
+	return server
+}
+
+// TestServeConnRejectsWrongToken checks that a connection sending the wrong
+// first line is closed before any RPC method runs, rather than falling
+// through to the JSON-RPC codec.
+func TestServeConnRejectsWrongToken(t *testing.T) {
+	s := &Server{authToken: "secret"}
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go s.serveConn(newTestRPCServer(t), serverConn)
+
+	if _, err := clientConn.Write([]byte("wrong-token\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	buf := make([]byte, 1)
+	if _, err := clientConn.Read(buf); err == nil {
+		t.Fatal("expected the connection to be closed after a bad auth token, got a successful read")
+	}
+}
+
+// TestServeConnAcceptsCorrectToken checks that a connection sending the
+// configured token as its first line is handed to the JSON-RPC codec and
+// can make calls normally afterward.
+func TestServeConnAcceptsCorrectToken(t *testing.T) {
+	s := &Server{authToken: "secret"}
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go s.serveConn(newTestRPCServer(t), serverConn)
+
+	if _, err := clientConn.Write([]byte("secret\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	client := jsonrpc.NewClient(clientConn)
+	defer client.Close()
+
+	var reply string
+	if err := client.Call("Echo.Echo", "hello", &reply); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if reply != "hello" {
+		t.Errorf("reply = %q, want %q", reply, "hello")
+	}
+}
+
+// TestServeConnNoAuthWhenTokenEmpty checks that an empty authToken leaves
+// the connection unauthenticated, preserving the documented opt-out used by
+// tests and deliberately trusted local sockets.
+func TestServeConnNoAuthWhenTokenEmpty(t *testing.T) {
+	s := &Server{}
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go s.serveConn(newTestRPCServer(t), serverConn)
+
+	client := jsonrpc.NewClient(clientConn)
+	defer client.Close()
+
+	var reply string
+	if err := client.Call("Echo.Echo", "hi", &reply); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if reply != "hi" {
+		t.Errorf("reply = %q, want %q", reply, "hi")
+	}
+}
@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"github.com/raedahgroup/godcr/wallet"
+)
+
+// walletService implements the RPC-visible wallet operations. Each method
+// follows the net/rpc convention of func(args, *reply) error so it can be
+// registered with rpc.Server.RegisterName.
+type walletService struct {
+	wallet *wallet.Wallet
+}
+
+// AccountsArgs selects which wallet to list accounts for.
+type AccountsArgs struct {
+	WalletID int
+}
+
+// AccountsReply carries the account listing for a wallet.
+type AccountsReply struct {
+	Accounts *wallet.Accounts
+}
+
+// Accounts lists the accounts of the given wallet.
+func (s *walletService) Accounts(args AccountsArgs, reply *AccountsReply) error {
+	accounts, err := s.wallet.GetAccounts(args.WalletID)
+	if err != nil {
+		return err
+	}
+
+	reply.Accounts = accounts
+	return nil
+}
+
+// TransactionsArgs carries a WalletID, which Transactions does not use.
+type TransactionsArgs struct {
+	WalletID int
+}
+
+// TransactionsReply carries the transaction history for a wallet.
+type TransactionsReply struct {
+	Transactions *wallet.Transactions
+}
+
+// Transactions returns the last known transaction history, as cached from
+// the most recent wallet.Send update. args.WalletID is ignored: the reply
+// is the whole cached list, not filtered to one wallet.
+func (s *walletService) Transactions(args TransactionsArgs, reply *TransactionsReply) error {
+	reply.Transactions = s.wallet.LastTransactions()
+	return nil
+}
+
+// SendArgs describes a send request.
+type SendArgs struct {
+	SourceAccount int
+	Destination   string
+	Amount        int64
+	Passphrase    string
+}
+
+// SendReply carries the broadcast result of a successful send.
+type SendReply struct {
+	Result wallet.Broadcast
+}
+
+// Send constructs, signs and broadcasts a transaction.
+func (s *walletService) Send(args SendArgs, reply *SendReply) error {
+	result, err := s.wallet.SendTransaction(args.SourceAccount, args.Destination, args.Amount, args.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	reply.Result = result
+	return nil
+}
+
+// SignMessageArgs describes a sign-message request.
+type SignMessageArgs struct {
+	SourceAccount int
+	Address       string
+	Passphrase    string
+	Message       string
+}
+
+// SignMessageReply carries the resulting signature.
+type SignMessageReply struct {
+	Signature wallet.Signature
+}
+
+// SignMessage signs message with the private key of address.
+func (s *walletService) SignMessage(args SignMessageArgs, reply *SignMessageReply) error {
+	sig, err := s.wallet.SignMessage(args.Passphrase, args.Address, args.Message)
+	if err != nil {
+		return err
+	}
+
+	reply.Signature = sig
+	return nil
+}
+
+// VerifyMessageArgs describes a verify-message request.
+type VerifyMessageArgs struct {
+	Address   string
+	Message   string
+	Signature string
+}
+
+// VerifyMessageReply carries the result of the verification.
+type VerifyMessageReply struct {
+	Valid bool
+}
+
+// VerifyMessage checks that signature is a valid signature of message by address.
+func (s *walletService) VerifyMessage(args VerifyMessageArgs, reply *VerifyMessageReply) error {
+	valid, err := s.wallet.VerifyMessage(args.Address, args.Message, args.Signature)
+	if err != nil {
+		return err
+	}
+
+	reply.Valid = valid
+	return nil
+}
+
+// RescanArgs selects which wallet to rescan.
+type RescanArgs struct {
+	WalletID int
+}
+
+// RescanReply acknowledges that a rescan was started.
+type RescanReply struct{}
+
+// Rescan starts a manual blocks rescan for the given wallet.
+func (s *walletService) Rescan(args RescanArgs, reply *RescanReply) error {
+	return s.wallet.RescanBlocks(args.WalletID)
+}
+
+// CancelRescanArgs selects which wallet's rescan to cancel.
+type CancelRescanArgs struct {
+	WalletID int
+}
+
+// CancelRescanReply acknowledges the cancellation.
+type CancelRescanReply struct{}
+
+// CancelRescan cancels an in-progress rescan for the given wallet.
+func (s *walletService) CancelRescan(args CancelRescanArgs, reply *CancelRescanReply) error {
+	s.wallet.CancelRescan(args.WalletID)
+	return nil
+}
+
+// SyncStatusArgs is empty; sync status is global to the loaded multiwallet.
+type SyncStatusArgs struct{}
+
+// SyncStatusReply carries the last known sync status, polled by clients
+// that want to emulate streaming by calling this repeatedly.
+type SyncStatusReply struct {
+	Status *wallet.SyncStatus
+}
+
+// SyncStatus returns the last sync status update received on wallet.Sync.
+func (s *walletService) SyncStatus(args SyncStatusArgs, reply *SyncStatusReply) error {
+	reply.Status = s.wallet.LastSyncStatus()
+	return nil
+}
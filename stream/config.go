@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"net/http"
+
+	"github.com/raedahgroup/godcr/ui/events"
+)
+
+// defaultListenAddress is used whenever a Config leaves ListenAddress
+// empty, so enabling the stream server without specifying an address binds
+// loopback-only rather than every interface, matching rpc.Config's default.
+const defaultListenAddress = "127.0.0.1:9112"
+
+// Config controls whether the streaming endpoint runs and where it
+// listens. A zero Config leaves it disabled.
+type Config struct {
+	Enabled       bool
+	ListenAddress string // defaults to loopback-only (see defaultListenAddress) when empty
+
+	// AuthToken gates every request behind a shared secret: a client must
+	// send it as the X-Auth-Token header before the SSE/WS endpoints will
+	// serve it. Same tradeoff as rpc.Config.AuthToken -- this feed carries
+	// live sync/tx/peer data, so it shouldn't be readable by an arbitrary
+	// local process just because the port is reachable.
+	AuthToken string
+}
+
+// StartIfEnabled starts the SSE/WebSocket server in the background when
+// cfg.Enabled is set, returning the running *http.Server so the caller can
+// Shutdown it. It is a no-op returning a nil *http.Server when disabled.
+func StartIfEnabled(cfg Config, mux *events.Mux) (*http.Server, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	addr := cfg.ListenAddress
+	if addr == "" {
+		addr = defaultListenAddress
+	}
+
+	streamServer := NewServer(mux)
+
+	handler := http.NewServeMux()
+	streamServer.RegisterHandlers(handler)
+
+	httpServer := &http.Server{Addr: addr, Handler: requireAuthToken(handler, cfg.AuthToken)}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("stream: " + err.Error())
+		}
+	}()
+
+	return httpServer, nil
+}
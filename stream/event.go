@@ -0,0 +1,44 @@
+package stream
+
+import "github.com/raedahgroup/godcr/ui/events"
+
+// Envelope is the stable, versioned JSON shape every streamed event is
+// wrapped in, so dashboards built against it don't need to understand Go
+// types or the internal events package. Type names are deliberately separate
+// from the Go type names in ui/events so a future internal refactor there
+// doesn't break external consumers.
+type Envelope struct {
+	Seq  uint64      `json:"seq"`
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// toEnvelope converts an ui/events value into the wire Envelope for it, or
+// reports ok=false for event types this endpoint doesn't publish (e.g. ones
+// added later that older dashboards shouldn't need to know about).
+func toEnvelope(seq uint64, ev interface{}) (Envelope, bool) {
+	switch e := ev.(type) {
+	case events.SyncStateEvent:
+		return Envelope{Seq: seq, Type: "sync_state", Data: syncStateData{Stage: int(e.Stage)}}, true
+	case events.SyncProgressEvent:
+		return Envelope{Seq: seq, Type: "sync_progress", Data: e.Report}, true
+	case events.RescanProgressEvent:
+		return Envelope{Seq: seq, Type: "rescan_progress", Data: e.Report}, true
+	case events.PeerCountEvent:
+		return Envelope{Seq: seq, Type: "peer_count", Data: peerCountData{Connected: e.Connected}}, true
+	case events.TxAttachedEvent:
+		return Envelope{Seq: seq, Type: "tx_attached", Data: e.Info}, true
+	case events.TxConfirmedEvent:
+		return Envelope{Seq: seq, Type: "tx_confirmed", Data: e.Info}, true
+	default:
+		return Envelope{}, false
+	}
+}
+
+type syncStateData struct {
+	Stage int `json:"stage"`
+}
+
+type peerCountData struct {
+	Connected int32 `json:"connected"`
+}
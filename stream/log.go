@@ -0,0 +1,5 @@
+package stream
+
+import "github.com/decred/slog"
+
+var log = slog.Disabled
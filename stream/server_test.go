@@ -0,0 +1,168 @@
+package stream
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raedahgroup/godcr/ui/events"
+)
+
+func TestRequireAuthTokenRejectsMissingOrWrongToken(t *testing.T) {
+	handler := requireAuthToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "secret")
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	for _, token := range []string{"", "wrong"} {
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		if token != "" {
+			req.Header.Set("X-Auth-Token", token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("token %q: status = %d, want %d", token, resp.StatusCode, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestRequireAuthTokenAllowsCorrectToken(t *testing.T) {
+	handler := requireAuthToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "secret")
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("X-Auth-Token", "secret")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestRequireAuthTokenNoopWhenEmpty(t *testing.T) {
+	called := false
+	handler := requireAuthToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}), "")
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if !called {
+		t.Error("expected the wrapped handler to run when no auth token is configured")
+	}
+}
+
+// TestServerReplaysBufferedEventsToSSEClient checks that a client
+// connecting to /events after an event was already posted is replayed it
+// (rather than only seeing events posted after it connects), and that it
+// keeps receiving events posted live afterward.
+func TestServerReplaysBufferedEventsToSSEClient(t *testing.T) {
+	mux := events.NewMux()
+	server := NewServer(mux)
+
+	if err := mux.Post(events.PeerCountEvent{Connected: 3}); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	// give run() a moment to pick the event up into the replay buffer
+	// before a client connects.
+	time.Sleep(20 * time.Millisecond)
+
+	handler := http.NewServeMux()
+	server.RegisterHandlers(handler)
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+
+	r := bufio.NewReader(resp.Body)
+
+	line := readDataLine(t, r)
+	if !strings.Contains(line, `"peer_count"`) || !strings.Contains(line, `"connected":3`) {
+		t.Errorf("replayed line = %q, want it to contain the posted PeerCountEvent", line)
+	}
+
+	if err := mux.Post(events.PeerCountEvent{Connected: 5}); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	line = readDataLine(t, r)
+	if !strings.Contains(line, `"connected":5`) {
+		t.Errorf("live line = %q, want it to contain the second PeerCountEvent", line)
+	}
+}
+
+// readDataLine reads lines off r until it finds one starting with "data: ",
+// the SSE payload line written by writeSSE, failing the test if none
+// arrives within a reasonable time.
+func readDataLine(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	type result struct {
+		line string
+		err  error
+	}
+	lines := make(chan result, 1)
+
+	go func() {
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				lines <- result{err: err}
+				return
+			}
+			if strings.HasPrefix(line, "data: ") {
+				lines <- result{line: line}
+				return
+			}
+		}
+	}()
+
+	select {
+	case res := <-lines:
+		if res.err != nil {
+			t.Fatalf("ReadString: %v", res.err)
+		}
+		return res.line
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an SSE data line")
+		return ""
+	}
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"image"
+
+	"decred.org/dcrdex/client/core"
+
+	"github.com/raedahgroup/godcr/dex"
+	"github.com/raedahgroup/godcr/rpc"
+	"github.com/raedahgroup/godcr/stream"
+	"github.com/raedahgroup/godcr/wallet"
+)
+
+// Config bundles the optional subsystems Window can start alongside the Gio
+// UI. A zero Config leaves all of them disabled, so existing callers of
+// CreateWindow are unaffected.
+type Config struct {
+	RPC    rpc.Config
+	Stream stream.Config
+	DEX    *core.Config // nil disables the DEX page
+}
+
+// CreateWindowWithConfig is CreateWindow plus cfg: it additionally starts
+// whichever optional subsystems cfg enables, wired to the same wallet the
+// UI itself uses. cmd/godcr's --headless mode should call RunHeadless
+// instead, which starts the same subsystems without creating a Gio window
+// at all.
+func CreateWindowWithConfig(wal *wallet.Wallet, decredIcons map[string]image.Image, cfg Config) (*Window, error) {
+	win, err := createWindow(wal, decredIcons)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcServer, err := rpc.StartIfEnabled(cfg.RPC, wal)
+	if err != nil {
+		return nil, err
+	}
+	win.rpc = rpcServer
+
+	streamServer, err := stream.StartIfEnabled(cfg.Stream, win.EventMux())
+	if err != nil {
+		return nil, err
+	}
+	win.stream = streamServer
+
+	if cfg.DEX != nil {
+		dexClient, err := dex.Start(cfg.DEX, wal)
+		if err != nil {
+			return nil, err
+		}
+		win.dex = dexClient
+	}
+	// DEXPage renders its own "not enabled" message when win.dex is nil, so
+	// it's always registered regardless of whether cfg.DEX was set.
+	win.pages[PageDEX] = win.DEXPage()
+
+	return win, nil
+}
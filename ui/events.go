@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"github.com/raedahgroup/godcr/ui/events"
+	"github.com/raedahgroup/godcr/wallet"
+)
+
+// forwardWalletEvents translates win.wallet's Sync updates into typed
+// events and posts them on win.mux. It runs for the lifetime of the
+// Window.
+func (win *Window) forwardWalletEvents() {
+	ForwardWalletEvents(win.wallet, win.mux)
+}
+
+// ForwardWalletEvents translates wal.Sync updates into typed events and
+// posts them on mux, so new stage producers (rescan, and in future
+// RPC-driven syncs) only need to post an event rather than add a case to
+// Loop's select. It blocks until wal.Sync is closed, so callers should run
+// it in its own goroutine; Window does so via forwardWalletEvents, and
+// RunHeadless uses it directly since it has no Window to hang the method
+// off of.
+func ForwardWalletEvents(wal *wallet.Wallet, mux *events.Mux) {
+	for update := range wal.Sync {
+		switch update.Stage {
+		case wallet.SyncCompleted, wallet.SyncStarted, wallet.SyncCanceled:
+			mux.Post(events.SyncStateEvent{Stage: update.Stage})
+		case wallet.HeadersFetchProgress, wallet.AddressDiscoveryProgress, wallet.HeadersRescanProgress:
+			mux.Post(events.SyncProgressEvent{Stage: update.Stage, Report: update.ProgressReport})
+		case wallet.BlocksRescanProgress:
+			mux.Post(events.RescanProgressEvent{Report: update.ProgressReport})
+		case wallet.BlocksRescanCanceled:
+			mux.Post(events.RescanProgressEvent{Canceled: true})
+		case wallet.PeersConnected:
+			mux.Post(events.PeerCountEvent{Connected: update.ConnectedPeers})
+		case wallet.BlockAttached:
+			mux.Post(events.TxAttachedEvent{Info: update.BlockInfo})
+		case wallet.BlockConfirmed:
+			mux.Post(events.TxConfirmedEvent{Info: update.ConfirmedTxn})
+		}
+	}
+}
+
+// handleEvent applies a single event from win.sub to Window state. It is
+// the one place that knows how each typed event affects what gets
+// rendered next frame, mirroring the role the old inline switch played in
+// Loop.
+func (win *Window) handleEvent(ev interface{}) {
+	switch e := ev.(type) {
+	case events.SyncStateEvent:
+		switch e.Stage {
+		case wallet.SyncCompleted:
+			win.updateSyncStatus(false, true)
+		case wallet.SyncStarted:
+			// dcrlibwallet triggers the SyncStart method several times
+			// without sending a SyncComplete signal when sync is done.
+			if !win.walletInfo.Synced {
+				win.updateSyncStatus(true, false)
+			}
+		case wallet.SyncCanceled:
+			win.updateSyncStatus(false, false)
+		}
+		wallet.CacheSyncStatus(win.walletSyncStatus)
+
+	case events.SyncProgressEvent:
+		win.updateSyncProgress(e.Report)
+		wallet.CacheSyncStatus(win.walletSyncStatus)
+
+	case events.RescanProgressEvent:
+		if e.Canceled {
+			win.updateRescanProgress(nil)
+			break
+		}
+		win.updateRescanProgress(e.Report)
+		if e.Report != nil && e.Report.RescanProgress == 100 {
+			win.wallet.GetAllTransactions()
+			win.wallet.GetMultiWalletInfo()
+		}
+
+	case events.PeerCountEvent:
+		win.updateConnectedPeers(e.Connected)
+		wallet.CacheSyncStatus(win.walletSyncStatus)
+
+	case events.TxAttachedEvent:
+		if win.walletInfo.Synced {
+			win.wallet.GetMultiWalletInfo()
+			win.updateSyncProgress(e.Info)
+			wallet.CacheSyncStatus(win.walletSyncStatus)
+		}
+
+	case events.TxConfirmedEvent:
+		// Baseline handled BlockConfirmed unconditionally, with no Synced
+		// gate and no GetMultiWalletInfo refresh; preserve that exactly.
+		win.updateSyncProgress(e.Info)
+		wallet.CacheSyncStatus(win.walletSyncStatus)
+	}
+}
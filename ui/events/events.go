@@ -0,0 +1,50 @@
+package events
+
+import "github.com/raedahgroup/godcr/wallet"
+
+// SyncProgressEvent is posted for every headers-fetch/address-discovery/
+// rescan progress update received on wallet.Wallet.Sync.
+type SyncProgressEvent struct {
+	Stage  wallet.SyncStage
+	Report *wallet.ProgressReport
+}
+
+// SyncStateEvent is posted when sync starts, completes or is canceled.
+type SyncStateEvent struct {
+	Stage wallet.SyncStage
+}
+
+// critical marks SyncStateEvent as one Mux.Post must never drop: a dropped
+// SyncCompleted/SyncCanceled would leave the UI stuck showing "syncing"
+// forever, unlike a missed progress tick which the next one supersedes.
+func (SyncStateEvent) critical() {}
+
+// TxAttachedEvent is posted when a new block is attached to the best
+// chain. Info holds the wallet.BlockInfo dcrlibwallet reported, mirroring
+// the flexible argument updateSyncProgress already accepted before this
+// event bus existed.
+type TxAttachedEvent struct {
+	Info interface{}
+}
+
+// TxConfirmedEvent is posted when a watched transaction reaches a new
+// confirmation count. It is kept distinct from TxAttachedEvent: unlike a
+// block attaching, a confirmation update was (and still is) handled
+// unconditionally, with no Synced gate and no GetMultiWalletInfo refresh.
+type TxConfirmedEvent struct {
+	Info interface{}
+}
+
+// RescanProgressEvent is posted for progress updates of a manual rescan
+// started via wallet.Wallet.RescanBlocks, and once more when the rescan
+// ends without completing (Canceled true, Report nil), so a canceled or
+// failed rescan isn't mistaken for a successful one that reached 100%.
+type RescanProgressEvent struct {
+	Report   *wallet.ProgressReport
+	Canceled bool
+}
+
+// PeerCountEvent is posted whenever the number of connected peers changes.
+type PeerCountEvent struct {
+	Connected int32
+}
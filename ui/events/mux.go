@@ -0,0 +1,191 @@
+// Package events provides a small typed pub/sub bus for Window. It is
+// modeled on go-ethereum's event.Mux/Subscription: producers (sync, rescan,
+// DEX, RPC, ...) Post typed events without knowing who, if anyone, is
+// listening, and Window subscribes to the event types it cares about and
+// fans them into its single render loop.
+package events
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// ErrMuxClosed is returned by Post once the Mux has been stopped.
+var ErrMuxClosed = errors.New("events: mux closed")
+
+// criticalEvent is implemented by event types Post must never drop, even
+// if a slow subscriber's buffer is full. Most events (progress ticks, peer
+// counts, ...) are fine to drop since a later one supersedes them; state
+// transitions are not.
+type criticalEvent interface {
+	critical()
+}
+
+// Mux dispatches posted events to every Subscription registered for that
+// event's concrete type.
+type Mux struct {
+	mu     sync.RWMutex
+	subs   map[reflect.Type][]*Subscription
+	closed bool
+}
+
+// NewMux creates an empty, ready to use Mux.
+func NewMux() *Mux {
+	return &Mux{subs: make(map[reflect.Type][]*Subscription)}
+}
+
+// Subscribe returns a Subscription that receives every event whose concrete
+// type matches one of types. types are sample values used only for their
+// type, e.g. Subscribe(SyncProgressEvent{}, PeerCountEvent{}).
+func (m *Mux) Subscribe(types ...interface{}) *Subscription {
+	sub := &Subscription{mux: m, ch: make(chan interface{}, 16), closing: make(chan struct{})}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range types {
+		rt := reflect.TypeOf(t)
+		m.subs[rt] = append(m.subs[rt], sub)
+	}
+
+	return sub
+}
+
+// Post delivers event to every subscription registered for its concrete
+// type. Most events are delivered best-effort: a subscriber that has
+// fallen behind drops them rather than stalling the producer. Events whose
+// type implements criticalEvent (state transitions, where dropping one
+// would leave a subscriber stuck) are instead delivered with a blocking
+// send, once per subscriber, performed without holding the Mux lock so it
+// can't deadlock against a concurrent Stop.
+func (m *Mux) Post(event interface{}) error {
+	m.mu.RLock()
+	if m.closed {
+		m.mu.RUnlock()
+		return ErrMuxClosed
+	}
+	subs := append([]*Subscription(nil), m.subs[reflect.TypeOf(event)]...)
+	m.mu.RUnlock()
+
+	_, blocking := event.(criticalEvent)
+	for _, sub := range subs {
+		sub.deliver(event, blocking)
+	}
+
+	return nil
+}
+
+// Stop closes every outstanding subscription. Further Posts return
+// ErrMuxClosed.
+func (m *Mux) Stop() {
+	m.mu.Lock()
+
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	subs := m.subs
+	m.subs = nil
+	m.mu.Unlock()
+
+	seen := make(map[*Subscription]bool)
+	for _, group := range subs {
+		for _, sub := range group {
+			if !seen[sub] {
+				sub.close()
+				seen[sub] = true
+			}
+		}
+	}
+}
+
+func (m *Mux) unsubscribe(sub *Subscription) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return
+	}
+	for t, group := range m.subs {
+		for i, s := range group {
+			if s == sub {
+				m.subs[t] = append(group[:i], group[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Subscription is a single listener's view of a Mux, returned by
+// Mux.Subscribe.
+type Subscription struct {
+	mux     *Mux
+	ch      chan interface{}
+	closing chan struct{} // closed by close() to abort any in-flight blocking deliver
+	once    sync.Once
+	wg      sync.WaitGroup // in-flight deliver calls; close() waits on this before closing ch
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Chan returns the channel events are delivered on. It is closed once the
+// subscription is unsubscribed or the owning Mux is stopped.
+func (s *Subscription) Chan() <-chan interface{} {
+	return s.ch
+}
+
+// Unsubscribe stops delivery to this subscription and closes its channel.
+func (s *Subscription) Unsubscribe() {
+	s.mux.unsubscribe(s)
+	s.close()
+}
+
+// deliver sends event on ch. It never holds mu while blocked waiting for
+// room in ch: a blocking critical-event send instead races the send against
+// closing, so a concurrent close() can always abort it rather than
+// deadlock waiting for a subscriber that's gone. wg tracks the send for the
+// lifetime of that race so close() can wait for it to finish before closing
+// ch, which is what makes the send-after-close race impossible without
+// holding a lock across the send.
+func (s *Subscription) deliver(event interface{}, blocking bool) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.wg.Add(1)
+	s.mu.Unlock()
+	defer s.wg.Done()
+
+	if blocking {
+		select {
+		case s.ch <- event:
+		case <-s.closing:
+		}
+		return
+	}
+
+	select {
+	case s.ch <- event:
+	case <-s.closing:
+	default:
+	}
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+
+		// Unblock any deliver already parked on a blocking send, then wait
+		// for it to actually return before closing ch: deliver never sends
+		// after observing closing closed, so once wg reaches zero no
+		// goroutine can still be racing this close.
+		close(s.closing)
+		s.wg.Wait()
+		close(s.ch)
+	})
+}
@@ -0,0 +1,164 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPostBestEffortDropsRatherThanBlock drives a non-critical event (one
+// that doesn't implement criticalEvent) into a subscriber whose channel is
+// already full, and checks Post returns immediately instead of blocking on
+// the producer -- the behavior PeerCountEvent and friends rely on so a slow
+// dashboard subscriber can't stall sync progress for everyone else.
+func TestPostBestEffortDropsRatherThanBlock(t *testing.T) {
+	mux := NewMux()
+	sub := mux.Subscribe(PeerCountEvent{})
+
+	for i := 0; i < cap(sub.ch)+1; i++ {
+		done := make(chan struct{})
+		go func() {
+			mux.Post(PeerCountEvent{Connected: int32(i)})
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("Post blocked on event %d despite being best-effort", i)
+		}
+	}
+}
+
+// TestPostCriticalBlocksUntilDelivered checks that a critical event (one
+// implementing criticalEvent, like SyncStateEvent) is not dropped once the
+// subscriber's buffer is full: Post must block until the subscriber drains
+// a slot, rather than silently losing a state transition.
+func TestPostCriticalBlocksUntilDelivered(t *testing.T) {
+	mux := NewMux()
+	sub := mux.Subscribe(SyncStateEvent{})
+
+	for i := 0; i < cap(sub.ch); i++ {
+		if err := mux.Post(SyncStateEvent{}); err != nil {
+			t.Fatalf("Post #%d: unexpected error %v", i, err)
+		}
+	}
+
+	postReturned := make(chan struct{})
+	go func() {
+		mux.Post(SyncStateEvent{})
+		close(postReturned)
+	}()
+
+	select {
+	case <-postReturned:
+		t.Fatal("Post on a critical event returned before the full subscriber buffer was drained")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-sub.Chan()
+
+	select {
+	case <-postReturned:
+	case <-time.After(time.Second):
+		t.Fatal("Post did not unblock after the subscriber drained a slot")
+	}
+}
+
+// TestStopUnblocksPendingCriticalSend reproduces the shutdown deadlock this
+// package was written to avoid: Stop() must be able to close a subscription
+// out from under a Post that's still blocked delivering a critical event to
+// it, rather than the two waiting on each other forever.
+func TestStopUnblocksPendingCriticalSend(t *testing.T) {
+	mux := NewMux()
+	sub := mux.Subscribe(SyncStateEvent{})
+
+	for i := 0; i < cap(sub.ch); i++ {
+		if err := mux.Post(SyncStateEvent{}); err != nil {
+			t.Fatalf("Post #%d: unexpected error %v", i, err)
+		}
+	}
+
+	postReturned := make(chan struct{})
+	go func() {
+		mux.Post(SyncStateEvent{})
+		close(postReturned)
+	}()
+
+	select {
+	case <-postReturned:
+		t.Fatal("Post returned before Stop; the subscriber buffer should still be full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stopReturned := make(chan struct{})
+	go func() {
+		mux.Stop()
+		close(stopReturned)
+	}()
+
+	select {
+	case <-stopReturned:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return; deadlocked against the blocked Post")
+	}
+
+	select {
+	case <-postReturned:
+	case <-time.After(time.Second):
+		t.Fatal("Post did not return after Stop closed the subscription")
+	}
+
+	// The events buffered before Stop are still delivered, as with
+	// Unsubscribe; the channel reads as closed once they are drained.
+	for i := 0; i < cap(sub.ch); i++ {
+		if _, ok := <-sub.Chan(); !ok {
+			t.Fatalf("sub.Chan() closed after %d buffered events, want %d", i, cap(sub.ch))
+		}
+	}
+	if _, ok := <-sub.Chan(); ok {
+		t.Error("expected sub.Chan() to be closed once Stop has run and its buffered events are drained")
+	}
+}
+
+// TestSubscribeUnsubscribe checks that Unsubscribe stops further delivery
+// and closes the subscription's channel, and that events posted before
+// Unsubscribe are still delivered in sequence, not invalidated.
+func TestSubscribeUnsubscribe(t *testing.T) {
+	mux := NewMux()
+	sub := mux.Subscribe(PeerCountEvent{})
+
+	if err := mux.Post(PeerCountEvent{Connected: 1}); err != nil {
+		t.Fatalf("Post: unexpected error %v", err)
+	}
+
+	sub.Unsubscribe()
+
+	ev, ok := <-sub.Chan()
+	if !ok {
+		t.Fatal("expected the event posted before Unsubscribe to still be delivered")
+	}
+	if got := ev.(PeerCountEvent).Connected; got != 1 {
+		t.Errorf("Connected = %d, want 1", got)
+	}
+
+	if _, ok := <-sub.Chan(); ok {
+		t.Error("expected sub.Chan() to be closed after Unsubscribe drains the buffered event")
+	}
+
+	if err := mux.Post(PeerCountEvent{Connected: 2}); err != nil {
+		t.Fatalf("Post after Unsubscribe: unexpected error %v", err)
+	}
+}
+
+// TestPostAfterStopReturnsErrMuxClosed checks that Post fails fast once the
+// Mux is stopped instead of silently discarding events or panicking on a
+// nil subs map.
+func TestPostAfterStopReturnsErrMuxClosed(t *testing.T) {
+	mux := NewMux()
+	mux.Subscribe(PeerCountEvent{})
+	mux.Stop()
+
+	if err := mux.Post(PeerCountEvent{}); err != ErrMuxClosed {
+		t.Errorf("Post after Stop: got %v, want ErrMuxClosed", err)
+	}
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/raedahgroup/godcr/dex"
+	"github.com/raedahgroup/godcr/rpc"
+	"github.com/raedahgroup/godcr/stream"
+	"github.com/raedahgroup/godcr/ui/events"
+	"github.com/raedahgroup/godcr/utils"
+	"github.com/raedahgroup/godcr/wallet"
+)
+
+// RunHeadless starts the subsystems cfg enables against wal and blocks
+// until an interrupt is received, without ever calling CreateWindow or
+// Loop. This is what cmd/godcr's --headless flag should call instead of
+// the normal CreateWindow/Loop pair, so godcr can be driven purely over
+// RPC with no Gio window.
+func RunHeadless(wal *wallet.Wallet, cfg Config) error {
+	rpcServer, err := rpc.StartIfEnabled(cfg.RPC, wal)
+	if err != nil {
+		return err
+	}
+	if rpcServer != nil {
+		defer rpcServer.Close()
+	}
+
+	// Stream needs an events.Mux the same way Window does, but headless has
+	// no Window to own one, so it creates its own and forwards wal.Sync
+	// into it directly.
+	mux := events.NewMux()
+	defer mux.Stop()
+	utils.Safe(func() { ForwardWalletEvents(wal, mux) }, nil)
+
+	streamServer, err := stream.StartIfEnabled(cfg.Stream, mux)
+	if err != nil {
+		return err
+	}
+	if streamServer != nil {
+		defer streamServer.Close()
+	}
+
+	if cfg.DEX != nil {
+		dexClient, err := dex.Start(cfg.DEX, wal)
+		if err != nil {
+			return err
+		}
+		defer dexClient.Shutdown()
+
+		// Headless has no Window to drain Notifications via
+		// handleDEXUpdate; something still has to, or relayNotifications
+		// blocks once its buffer fills and stalls Core's own notification
+		// feed. Discard them here instead.
+		utils.Safe(func() {
+			for range dexClient.Notifications {
+			}
+		}, nil)
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"decred.org/dcrdex/client/core"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/raedahgroup/godcr/dex"
+	"github.com/raedahgroup/godcr/ui/decredmaterial"
+	"github.com/raedahgroup/godcr/utils"
+)
+
+const PageDEX = "dex"
+
+// dcrAssetID is BIP-44 coin type 42 (Decred), the only asset a bond can
+// currently be posted from since the DEX client is backed by a
+// Decred-only wallet.Wallet.
+const dcrAssetID = 42
+
+// maxStoredDEXOrders bounds how many entries handleDEXUpdate keeps, so a
+// long-running session watching a busy market doesn't grow dexOrders
+// forever.
+const maxStoredDEXOrders = 100
+
+// dexPage renders server registration/bond posting, market selection, the
+// order book, order placement and the active orders/matches view. It is
+// registered in win.pages as PageDEX whether or not a DEX client is
+// attached, and is shown by setting win.current = PageDEX; it is not added
+// to the navigation tabs.
+type dexPage struct {
+	registerButton   decredmaterial.Button
+	registerWidget   widget.Button
+	bondEditor       widget.Editor
+	passphraseEditor widget.Editor
+
+	placeBidButton decredmaterial.Button
+	placeBidWidget widget.Button
+	placeAskButton decredmaterial.Button
+	placeAskWidget widget.Button
+
+	rateEditor widget.Editor
+	qtyEditor  widget.Editor
+
+	marketButtons  map[string]*widget.Button
+	selectedHost   string
+	selectedMarket string
+}
+
+func (win *Window) DEXPage() layout.Widget {
+	page := &dexPage{
+		registerButton: win.theme.Button("Register & post bond"),
+		placeBidButton: win.theme.Button("Place bid"),
+		placeAskButton: win.theme.Button("Place ask"),
+		marketButtons:  make(map[string]*widget.Button),
+	}
+	page.passphraseEditor.Mask = '*'
+
+	return func() {
+		if win.dex == nil {
+			win.theme.Body1("DEX trading is not enabled for this wallet.").Layout(win.gtx)
+			return
+		}
+
+		layout.Flex{Axis: layout.Vertical}.Layout(win.gtx,
+			layout.Rigid(func() {
+				win.theme.H5("Decred DEX").Layout(win.gtx)
+			}),
+			layout.Rigid(func() {
+				win.layoutRegistration(page)
+			}),
+			layout.Rigid(func() {
+				win.layoutMarketList(page)
+			}),
+			layout.Rigid(func() {
+				win.layoutOrderBook(page)
+			}),
+			layout.Rigid(func() {
+				win.layoutOrderForm(page)
+			}),
+			layout.Rigid(func() {
+				win.layoutActiveOrders(page)
+			}),
+		)
+	}
+}
+
+func (win *Window) layoutRegistration(page *dexPage) {
+	layout.Flex{}.Layout(win.gtx,
+		layout.Rigid(func() {
+			win.theme.Editor("Bond amount (atoms)").Layout(win.gtx, &page.bondEditor)
+		}),
+		layout.Rigid(func() {
+			win.theme.Editor("Wallet passphrase").Layout(win.gtx, &page.passphraseEditor)
+		}),
+		layout.Rigid(func() {
+			if page.registerButton.Button(win.gtx, &page.registerWidget) {
+				win.registerDEX(page)
+			}
+		}),
+	)
+}
+
+// registerDEX validates the bond amount page.bondEditor holds and, if
+// valid, dispatches Register with the wallet passphrase and bond asset/
+// amount core.PostBond requires alongside Addr.
+func (win *Window) registerDEX(page *dexPage) {
+	bond, err := strconv.ParseUint(page.bondEditor.Text(), 10, 64)
+	if err != nil {
+		win.err = "invalid bond amount: " + err.Error()
+		return
+	}
+	if bond == 0 {
+		win.err = "bond amount must be greater than zero"
+		return
+	}
+
+	form := &core.PostBondForm{
+		Addr:  page.selectedHost,
+		Asset: dcrAssetID,
+		Bond:  bond,
+		Pass:  []byte(page.passphraseEditor.Text()),
+	}
+
+	utils.Safe(func() {
+		_, err := win.dex.Register(form)
+		win.dexResults <- err
+	}, win.reportPanic)
+}
+
+func (win *Window) layoutMarketList(page *dexPage) {
+	for host, xc := range win.dex.Markets() {
+		host := host
+		for marketName := range xc.Markets {
+			marketName := marketName
+			btn, ok := page.marketButtons[host+marketName]
+			if !ok {
+				btn = new(widget.Button)
+				page.marketButtons[host+marketName] = btn
+			}
+
+			label := win.theme.Body2(marketName)
+			if host == page.selectedHost && marketName == page.selectedMarket {
+				label = win.theme.Body2(marketName + " (selected)")
+			}
+
+			if btn.Clicked(win.gtx) {
+				page.selectedHost = host
+				page.selectedMarket = marketName
+			}
+			label.Layout(win.gtx)
+		}
+	}
+}
+
+func (win *Window) layoutOrderBook(page *dexPage) {
+	decredmaterial.Card{}.Layout(win.gtx, func() {
+		win.theme.Body2("Order book for " + page.selectedMarket).Layout(win.gtx)
+	})
+}
+
+func (win *Window) layoutOrderForm(page *dexPage) {
+	layout.Flex{}.Layout(win.gtx,
+		layout.Rigid(func() {
+			win.theme.Editor("Rate").Layout(win.gtx, &page.rateEditor)
+		}),
+		layout.Rigid(func() {
+			win.theme.Editor("Quantity").Layout(win.gtx, &page.qtyEditor)
+		}),
+		layout.Rigid(func() {
+			if page.placeBidButton.Button(win.gtx, &page.placeBidWidget) {
+				win.placeDEXOrder(page, false)
+			}
+		}),
+		layout.Rigid(func() {
+			if page.placeAskButton.Button(win.gtx, &page.placeAskWidget) {
+				win.placeDEXOrder(page, true)
+			}
+		}),
+	)
+}
+
+func (win *Window) placeDEXOrder(page *dexPage, sell bool) {
+	rate, err := strconv.ParseUint(page.rateEditor.Text(), 10, 64)
+	if err != nil {
+		win.err = "invalid rate: " + err.Error()
+		return
+	}
+	if rate == 0 {
+		win.err = "rate must be greater than zero"
+		return
+	}
+
+	qty, err := strconv.ParseUint(page.qtyEditor.Text(), 10, 64)
+	if err != nil {
+		win.err = "invalid quantity: " + err.Error()
+		return
+	}
+	if qty == 0 {
+		win.err = "quantity must be greater than zero"
+		return
+	}
+
+	form := &core.TradeForm{
+		Host:    page.selectedHost,
+		Market:  page.selectedMarket,
+		Sell:    sell,
+		Rate:    rate,
+		Qty:     qty,
+		IsLimit: true,
+	}
+
+	utils.Safe(func() {
+		_, err := win.dex.PlaceOrder(form)
+		win.dexResults <- err
+	}, win.reportPanic)
+}
+
+func (win *Window) layoutActiveOrders(page *dexPage) {
+	win.theme.H6("Active orders & matches").Layout(win.gtx)
+	for _, o := range win.dexOrders {
+		win.theme.Body2(o).Layout(win.gtx)
+	}
+}
+
+// dexNotifications returns the DEX notification channel, or nil when no DEX
+// client is attached. Selecting on a nil channel blocks forever, which is
+// exactly what we want until a DEX client is started.
+func (win *Window) dexNotifications() chan dex.Update {
+	if win.dex == nil {
+		return nil
+	}
+	return win.dex.Notifications
+}
+
+// handleDEXUpdate records order/match notifications so the active-orders
+// view reflects them next frame; other notification kinds (connectivity,
+// bond confirmations, ...) only invalidate the window, since they aren't
+// orders and shouldn't be mislabeled as such in dexOrders.
+func (win *Window) handleDEXUpdate(update dex.Update) {
+	switch n := update.Notification.(type) {
+	case *core.OrderNote, *core.MatchNote:
+		win.dexOrders = append(win.dexOrders, fmt.Sprintf("%v", n))
+		if len(win.dexOrders) > maxStoredDEXOrders {
+			win.dexOrders = win.dexOrders[len(win.dexOrders)-maxStoredDEXOrders:]
+		}
+	}
+
+	win.window.Invalidate()
+}
+
+// handleDEXResult surfaces the error (if any) from a Register/PlaceOrder
+// call dispatched via utils.Safe on win.dexResults. Only Loop (which reads
+// win.dexResults) ever touches win.err for these calls, the same way
+// recordPanic is the only writer for a recovered panic, so a slow DEX
+// round-trip can't race Window's own reads/writes of win.err on the
+// FrameEvent goroutine.
+func (win *Window) handleDEXResult(err error) {
+	if err != nil {
+		win.err = err.Error()
+	}
+	win.window.Invalidate()
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+
+	"github.com/raedahgroup/godcr/utils"
+)
+
+const PageDiagnostics = "diagnostics"
+
+// reportPanic is passed to utils.Safe as every wrapped goroutine's onPanic
+// callback. It logs the panic and hands it to Loop (via win.panics) so it
+// can be recorded and surfaced as a modal without racing Window state from
+// whatever goroutine panicked.
+func (win *Window) reportPanic(info utils.PanicInfo) {
+	log.Error(fmt.Sprintf("recovered panic: %v\n%s", info.Recovered, info.Stack))
+
+	select {
+	case win.panics <- info:
+	default:
+		// panics channel full; recordPanic hasn't caught up yet, drop rather
+		// than block the recovering goroutine.
+	}
+}
+
+// recordPanic appends info to the ring buffer backing the diagnostics page
+// and pops up the error dialog, mirroring how wallet errors are surfaced on
+// win.wallet.Send. win.window is nil in tests that drive this path without
+// a full Gio window; skip the redraw hint rather than nil-deref in that
+// case.
+func (win *Window) recordPanic(info utils.PanicInfo) {
+	win.lastPanics = append(win.lastPanics, info)
+	if len(win.lastPanics) > maxStoredPanics {
+		win.lastPanics = win.lastPanics[len(win.lastPanics)-maxStoredPanics:]
+	}
+
+	win.err = fmt.Sprintf("%v", info.Recovered)
+	if win.window != nil {
+		win.window.Invalidate()
+	}
+}
+
+// DiagnosticsPage lists the last panics recovered by utils.Safe, most
+// recent first, so a report can be filed without needing to reproduce the
+// crash under a debugger.
+func (win *Window) DiagnosticsPage() layout.Widget {
+	return func() {
+		win.theme.H5("Diagnostics").Layout(win.gtx)
+
+		if len(win.lastPanics) == 0 {
+			win.theme.Body1("No panics recorded this session.").Layout(win.gtx)
+			return
+		}
+
+		for i := len(win.lastPanics) - 1; i >= 0; i-- {
+			p := win.lastPanics[i]
+			win.theme.Body2(fmt.Sprintf("%v", p.Recovered)).Layout(win.gtx)
+			win.theme.Caption(string(p.Stack)).Layout(win.gtx)
+		}
+	}
+}
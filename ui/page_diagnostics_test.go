@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/raedahgroup/godcr/utils"
+)
+
+// TestWindowSurvivesPageHandlerPanic drives the panic path chunk0-5 added
+// end to end, through Window rather than utils.Safe in isolation: a page
+// handler's render closure panics inside utils.Safe, the same way Window
+// dispatches win.keyEvents and forwardWalletEvents, and the panic must
+// come out the other side recorded on win (ready for DiagnosticsPage to
+// render) rather than crashing the test binary.
+func TestWindowSurvivesPageHandlerPanic(t *testing.T) {
+	win := &Window{panics: make(chan utils.PanicInfo, maxStoredPanics)}
+
+	utils.Safe(func() {
+		panic("deliberate panic inside a page handler")
+	}, win.reportPanic)
+
+	win.recordPanic(<-win.panics)
+
+	if len(win.lastPanics) != 1 {
+		t.Fatalf("expected 1 recorded panic, got %d", len(win.lastPanics))
+	}
+	if win.err != "deliberate panic inside a page handler" {
+		t.Errorf("unexpected win.err: %q", win.err)
+	}
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/raedahgroup/godcr/ui/decredmaterial"
+	"github.com/raedahgroup/godcr/wallet"
+)
+
+const PageRescan = "rescan"
+
+// rescanPage holds the widgets used to render the manual rescan page,
+// shown by setting win.current = PageRescan.
+type rescanPage struct {
+	startButton  decredmaterial.Button
+	startWidget  widget.Button
+	cancelButton decredmaterial.Button
+	cancelWidget widget.Button
+	progressBar  decredmaterial.ProgressBar
+}
+
+func (win *Window) RescanPage() layout.Widget {
+	page := &rescanPage{
+		startButton:  win.theme.Button("Start rescan"),
+		cancelButton: win.theme.Button("Cancel"),
+		progressBar:  win.theme.ProgressBar(),
+	}
+
+	return func() {
+		report := win.rescanUpdate
+		if report == nil {
+			if page.startButton.Button(win.gtx, &page.startWidget) {
+				win.startRescan()
+			}
+
+			layout.Flex{Axis: layout.Vertical}.Layout(win.gtx,
+				layout.Rigid(func() {
+					win.theme.Body1("No rescan in progress.").Layout(win.gtx)
+				}),
+				layout.Rigid(func() {
+					page.startButton.Layout(win.gtx, &page.startWidget)
+				}),
+			)
+			return
+		}
+
+		if page.cancelButton.Button(win.gtx, &page.cancelWidget) {
+			win.wallet.CancelRescan(win.selectedWallet)
+		}
+
+		layout.Flex{Axis: layout.Vertical}.Layout(win.gtx,
+			layout.Rigid(func() {
+				win.theme.H5("Rescanning blocks").Layout(win.gtx)
+			}),
+			layout.Rigid(func() {
+				win.theme.Body1(rescanHeightsLabel(report, win.walletInfo.BestBlockHeight)).Layout(win.gtx)
+			}),
+			layout.Rigid(func() {
+				page.progressBar.Layout(win.gtx, int(report.RescanProgress))
+			}),
+			layout.Rigid(func() {
+				win.theme.Body2(rescanETALabel(report)).Layout(win.gtx)
+			}),
+			layout.Rigid(func() {
+				page.cancelButton.Layout(win.gtx, &page.cancelWidget)
+			}),
+		)
+	}
+}
+
+// rescanHeightsLabel renders the current rescan height against bestHeight,
+// the wallet's best known block height, not TotalHeadersToFetch (a
+// headers-fetch metric that has nothing to do with how far a blocks
+// rescan has to go).
+func rescanHeightsLabel(report *wallet.ProgressReport, bestHeight int32) string {
+	return fmt.Sprintf("%d / %d", report.CurrentRescanHeight, bestHeight)
+}
+
+func rescanETALabel(report *wallet.ProgressReport) string {
+	return fmt.Sprintf("%ds remaining", report.RescanTimeRemaining)
+}
+
+// updateRescanProgress records the latest rescan progress report so the
+// rescan page can render it on the next frame.
+func (win *Window) updateRescanProgress(report *wallet.ProgressReport) {
+	win.rescanning = report != nil && report.RescanProgress < 100
+	win.rescanUpdate = report
+	win.window.Invalidate()
+}
+
+// startRescan starts a manual blocks rescan for the currently selected
+// wallet, the only trigger for RescanPage's progress view: without it the
+// page could never leave its "no rescan in progress" state.
+func (win *Window) startRescan() {
+	if err := win.wallet.RescanBlocks(win.selectedWallet); err != nil {
+		win.err = err.Error()
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"errors"
 	"image"
+	"net/http"
 	"time"
 
 	"gioui.org/app"
@@ -11,10 +12,18 @@ import (
 	"gioui.org/layout"
 
 	"github.com/raedahgroup/dcrlibwallet"
+	"github.com/raedahgroup/godcr/dex"
+	"github.com/raedahgroup/godcr/rpc"
 	"github.com/raedahgroup/godcr/ui/decredmaterial"
+	"github.com/raedahgroup/godcr/ui/events"
+	"github.com/raedahgroup/godcr/utils"
 	"github.com/raedahgroup/godcr/wallet"
 )
 
+// maxStoredPanics bounds how many recovered panics the diagnostics page
+// keeps around; older ones are dropped.
+const maxStoredPanics = 25
+
 // Window represents the app window (and UI in general). There should only be one.
 // Window uses an internal state of booleans to determine what the window is currently displaying.
 type Window struct {
@@ -35,9 +44,26 @@ type Window struct {
 	signatureResult *wallet.Signature
 
 	selectedAccount int
+	selectedWallet  int
 	txAuthor        dcrlibwallet.TxAuthor
 	broadcastResult wallet.Broadcast
 
+	rescanning      bool
+	rescanUpdate    *wallet.ProgressReport
+
+	dex        *dex.DEX
+	dexOrders  []string
+	dexResults chan error
+
+	mux *events.Mux
+	sub *events.Subscription
+
+	rpc    *rpc.Server
+	stream *http.Server
+
+	panics     chan utils.PanicInfo
+	lastPanics []utils.PanicInfo
+
 	selected int
 	states
 
@@ -57,6 +83,13 @@ type Window struct {
 // app.NewWindow() which does not support being called more
 // than once.
 func CreateWindow(wal *wallet.Wallet, decredIcons map[string]image.Image) (*Window, error) {
+	return CreateWindowWithConfig(wal, decredIcons, Config{})
+}
+
+// createWindow does the unconditional part of window setup; CreateWindow
+// and CreateWindowWithConfig both call it before starting whichever
+// optional subsystems their Config enables.
+func createWindow(wal *wallet.Wallet, decredIcons map[string]image.Image) (*Window, error) {
 	win := new(Window)
 	win.window = app.NewWindow(app.Title("godcr"))
 	theme := decredmaterial.NewTheme()
@@ -77,12 +110,42 @@ func CreateWindow(wal *wallet.Wallet, decredIcons map[string]image.Image) (*Wind
 	win.current = PageOverview
 	win.dialog = func() {}
 	win.keyEvents = make(chan *key.Event)
+	win.panics = make(chan utils.PanicInfo, maxStoredPanics)
+	win.dexResults = make(chan error, 4)
+
+	win.mux = events.NewMux()
+	win.sub = win.mux.Subscribe(
+		events.SyncStateEvent{},
+		events.SyncProgressEvent{},
+		events.RescanProgressEvent{},
+		events.TxAttachedEvent{},
+		events.TxConfirmedEvent{},
+		events.PeerCountEvent{},
+	)
+	utils.Safe(win.forwardWalletEvents, win.reportPanic)
 
 	win.initWidgets()
 	win.addPages(decredIcons)
+
+	// RescanPage is shown by setting win.current = PageRescan; no other
+	// page links to it. Its own Start button calls RescanBlocks.
+	win.pages[PageRescan] = win.RescanPage()
+
+	// DiagnosticsPage is reachable the same way, by setting
+	// win.current = PageDiagnostics.
+	win.pages[PageDiagnostics] = win.DiagnosticsPage()
+
 	return win, nil
 }
 
+// EventMux returns the Window's event mux, so a sibling service (such as
+// stream.Server) can subscribe to the same sync/rescan/peer/tx events
+// Window itself renders, without Window needing to know that service
+// exists.
+func (win *Window) EventMux() *events.Mux {
+	return win.mux
+}
+
 func (win *Window) unloaded() {
 	lbl := win.theme.H3("Multiwallet not loaded\nIs another instance open?")
 	for {
@@ -121,39 +184,39 @@ func (win *Window) Loop(shutdown chan int) {
 				break
 			}
 			win.updateStates(e.Resp)
+			wallet.CacheTransactions(win.walletTransactions)
 
-		case update := <-win.wallet.Sync:
-			switch update.Stage {
-			case wallet.SyncCompleted:
-				win.updateSyncStatus(false, true)
-			case wallet.SyncStarted:
-				// dcrlibwallet triggers the SyncStart method several times
-				// without sending a SyncComplete signal when sync is done.
-				if !win.walletInfo.Synced {
-					win.updateSyncStatus(true, false)
-				}
-			case wallet.SyncCanceled:
-				win.updateSyncStatus(false, false)
-			case wallet.HeadersFetchProgress:
-				win.updateSyncProgress(update.ProgressReport)
-			case wallet.AddressDiscoveryProgress:
-				win.updateSyncProgress(update.ProgressReport)
-			case wallet.HeadersRescanProgress:
-				win.updateSyncProgress(update.ProgressReport)
-			case wallet.PeersConnected:
-				win.updateConnectedPeers(update.ConnectedPeers)
-			case wallet.BlockAttached:
-				if win.walletInfo.Synced {
-					win.wallet.GetMultiWalletInfo()
-					win.updateSyncProgress(update.BlockInfo)
-				}
-			case wallet.BlockConfirmed:
-				win.updateSyncProgress(update.ConfirmedTxn)
+		case ev, ok := <-win.sub.Chan():
+			if !ok {
+				break
+			}
+			win.handleEvent(ev)
+
+		case update, ok := <-win.dexNotifications():
+			if !ok {
+				break
 			}
+			win.handleDEXUpdate(update)
+
+		case info := <-win.panics:
+			win.recordPanic(info)
+
+		case err := <-win.dexResults:
+			win.handleDEXResult(err)
 
 		case e := <-win.window.Events():
 			switch evt := e.(type) {
 			case system.DestroyEvent:
+				if win.dex != nil {
+					win.dex.Shutdown()
+				}
+				if win.rpc != nil {
+					win.rpc.Close()
+				}
+				if win.stream != nil {
+					win.stream.Close()
+				}
+				win.mux.Stop()
 				close(shutdown)
 				return
 			case system.FrameEvent:
@@ -178,9 +241,9 @@ func (win *Window) Loop(shutdown chan int) {
 				win.HandleInputs()
 				evt.Frame(win.gtx.Ops)
 			case key.Event:
-				go func() {
+				utils.Safe(func() {
 					win.keyEvents <- &evt
-				}()
+				}, win.reportPanic)
 			case nil:
 				// Ignore
 			default:
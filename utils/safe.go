@@ -0,0 +1,30 @@
+// Package utils holds small helpers shared across godcr's subsystems that
+// don't belong to any one of them in particular.
+package utils
+
+import "runtime/debug"
+
+// PanicInfo captures a panic recovered by Safe: the recovered value and a
+// formatted stack trace taken at the point of the panic.
+type PanicInfo struct {
+	Recovered interface{}
+	Stack     []byte
+}
+
+// Safe runs fn on its own goroutine, recovering any panic so a bug in one
+// page handler can't take the whole process down. If fn panics, onPanic
+// (which may be nil) is called with the recovered PanicInfo.
+//
+// Every `go func(){ ... }()` spawned from the ui package should go through
+// Safe instead, so panics end up in one place rather than crashing the
+// window.
+func Safe(fn func(), onPanic func(PanicInfo)) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil && onPanic != nil {
+				onPanic(PanicInfo{Recovered: r, Stack: debug.Stack()})
+			}
+		}()
+		fn()
+	}()
+}
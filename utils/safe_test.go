@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestSafeRecoversPanic proves that a panic inside the wrapped function is
+// recovered and reported, rather than crashing the test binary.
+func TestSafeRecoversPanic(t *testing.T) {
+	var (
+		wg        sync.WaitGroup
+		recovered interface{}
+	)
+
+	wg.Add(1)
+	Safe(func() {
+		panic("deliberate panic inside a page handler")
+	}, func(info PanicInfo) {
+		recovered = info.Recovered
+		if len(info.Stack) == 0 {
+			t.Error("expected a non-empty stack trace")
+		}
+		wg.Done()
+	})
+
+	wg.Wait()
+
+	if recovered != "deliberate panic inside a page handler" {
+		t.Errorf("unexpected recovered value: %v", recovered)
+	}
+}
+
+// TestSafeRunsFunction proves the happy path still runs fn to completion
+// when it doesn't panic, and never calls onPanic.
+func TestSafeRunsFunction(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	ran := false
+	Safe(func() {
+		ran = true
+		wg.Done()
+	}, func(PanicInfo) {
+		t.Error("onPanic should not be called when fn does not panic")
+	})
+
+	wg.Wait()
+
+	if !ran {
+		t.Error("expected fn to run")
+	}
+}
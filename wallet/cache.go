@@ -0,0 +1,63 @@
+package wallet
+
+import "sync"
+
+// cache holds the most recent state pushed over the Send/Sync channels so
+// consumers that aren't the single long-lived select loop reading those
+// channels (like the rpc package) can read current values without racing
+// it. It is written from ui.Window's own handling of Send/Sync updates,
+// right after Window updates its own walletTransactions/walletSyncStatus
+// fields from the same update, so the two views never disagree.
+var cache struct {
+	sync.RWMutex
+	transactions *Transactions
+	syncStatus   *SyncStatus
+}
+
+// CacheTransactions records t as the most recently known transaction list.
+// Called by ui.Window after it processes a Send update that refreshed
+// walletTransactions.
+func CacheTransactions(t *Transactions) {
+	cache.Lock()
+	defer cache.Unlock()
+	if t == nil {
+		cache.transactions = nil
+		return
+	}
+	// store a copy: t is Window's own field, which it keeps mutating.
+	c := *t
+	cache.transactions = &c
+}
+
+// CacheSyncStatus records s as the most recently known sync status. Called
+// by ui.Window after it processes a Sync update that refreshed
+// walletSyncStatus.
+func CacheSyncStatus(s *SyncStatus) {
+	cache.Lock()
+	defer cache.Unlock()
+	if s == nil {
+		cache.syncStatus = nil
+		return
+	}
+	// store a copy: s is Window's own field, which it keeps mutating.
+	c := *s
+	cache.syncStatus = &c
+}
+
+// LastTransactions returns the transaction list as of the most recent
+// GetAllTransactions response, or nil before ui.Window has processed its
+// first Send update (after that and before any GetAllTransactions
+// response, it is an empty list).
+func (wal *Wallet) LastTransactions() *Transactions {
+	cache.RLock()
+	defer cache.RUnlock()
+	return cache.transactions
+}
+
+// LastSyncStatus returns the sync status as of the most recent wallet.Sync
+// update, or nil if none has been received yet.
+func (wal *Wallet) LastSyncStatus() *SyncStatus {
+	cache.RLock()
+	defer cache.RUnlock()
+	return cache.syncStatus
+}
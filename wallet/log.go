@@ -0,0 +1,5 @@
+package wallet
+
+import "github.com/decred/slog"
+
+var log = slog.Disabled
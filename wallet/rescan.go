@@ -0,0 +1,94 @@
+package wallet
+
+import "github.com/raedahgroup/dcrlibwallet"
+
+// BlocksRescanProgress is sent on the Sync channel while a manual blocks
+// rescan (triggered via Wallet.RescanBlocks) is in progress. It reuses the
+// same ProgressReport shape as HeadersRescanProgress so the UI can share
+// its rendering code between the initial sync rescan and a user-triggered
+// one.
+const BlocksRescanProgress SyncStage = HeadersRescanProgress + 100
+
+// BlocksRescanCanceled is sent on the Sync channel when a manual blocks
+// rescan ends without completing -- canceled via CancelRescan, or failed
+// inside dcrlibwallet -- so a listener watching for RescanProgress == 100
+// (see ui/events.go's handleEvent) can't mistake an aborted rescan for a
+// finished one.
+const BlocksRescanCanceled SyncStage = BlocksRescanProgress + 1
+
+// RescanBlocks starts (or restarts) a rescan of the wallet's transactions
+// against the chain, registering a BlocksRescanProgressListener that
+// translates dcrlibwallet's progress callbacks onto the Sync channel as
+// BlocksRescanProgress updates.
+func (wal *Wallet) RescanBlocks(walletID int) error {
+	w := wal.multi.WalletWithID(walletID)
+	if w == nil {
+		return ErrIDNotExist
+	}
+
+	if err := w.AddBlocksRescanProgressListener(&blocksRescanProgressListener{wal: wal}); err != nil {
+		return err
+	}
+
+	if err := w.RescanBlocks(); err != nil {
+		w.RemoveBlocksRescanProgressListener()
+		return err
+	}
+
+	return nil
+}
+
+// CancelRescan stops an in-progress blocks rescan started with RescanBlocks.
+func (wal *Wallet) CancelRescan(walletID int) {
+	w := wal.multi.WalletWithID(walletID)
+	if w == nil {
+		return
+	}
+
+	w.CancelRescan()
+}
+
+// blocksRescanProgressListener implements dcrlibwallet's
+// BlocksRescanProgressListener, relaying every callback onto wal.Sync as a
+// BlocksRescanProgress update the same way the initial sync's headers
+// rescan progress is already relayed.
+type blocksRescanProgressListener struct {
+	wal *Wallet
+}
+
+func (l *blocksRescanProgressListener) OnBlocksRescanStarted(walletID int) {
+	l.wal.Sync <- Update{
+		Stage:          BlocksRescanProgress,
+		ProgressReport: &ProgressReport{RescanProgress: 0},
+	}
+}
+
+func (l *blocksRescanProgressListener) OnBlocksRescanProgress(progress *dcrlibwallet.HeadersRescanProgressReport) {
+	l.wal.Sync <- Update{
+		Stage: BlocksRescanProgress,
+		ProgressReport: &ProgressReport{
+			CurrentRescanHeight: progress.CurrentRescanHeight,
+			TotalHeadersToFetch: progress.TotalHeadersToFetch,
+			RescanProgress:      progress.RescanProgress,
+			RescanTimeRemaining: progress.RescanTimeRemaining,
+		},
+	}
+}
+
+func (l *blocksRescanProgressListener) OnBlocksRescanEnded(walletID int, err error) {
+	w := l.wal.multi.WalletWithID(walletID)
+	if w != nil {
+		w.RemoveBlocksRescanProgressListener()
+	}
+
+	if err != nil {
+		log.Errorf("blocks rescan for wallet %d ended with error: %v", walletID, err)
+		l.wal.Sync <- Update{Stage: BlocksRescanCanceled}
+		return
+	}
+
+	l.wal.Sync <- Update{
+		Stage:          BlocksRescanProgress,
+		ProgressReport: &ProgressReport{RescanProgress: 100},
+	}
+}